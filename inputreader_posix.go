@@ -0,0 +1,90 @@
+//go:build !windows && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+// +build !windows,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+// nolint:revive
+package tea
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// newInputReader returns a cancelable input reader. If the passed reader is an
+// *os.File, the cancel method can be used to interrupt a blocking read call.
+// In this case, the cancel method returns true if the call was cancelled
+// successfully. If the input reader is not a *os.File, the cancel function
+// does nothing and always returns false.
+//
+// This implementation relies on the Go runtime's netpoller rather than a
+// hand-rolled epoll/kqueue/select loop: the file is put into non-blocking
+// mode and re-opened through os.NewFile, which the runtime then parks on
+// internally. Cancellation is done by shifting the read deadline into the
+// past, which unblocks the in-flight Read with os.ErrDeadlineExceeded.
+//
+// reader's fd is borrowed, not owned (it's frequently os.Stdin), so we dup
+// it before flipping on non-blocking mode and handing it to os.NewFile:
+// Close only ever closes our copy, so the caller's fd is never closed out
+// from under it. O_NONBLOCK is shared between the dup and the caller's fd,
+// so the caller's fd is non-blocking while the reader is open; Close clears
+// the flag again before closing our copy.
+func newInputReader(reader io.Reader) (inputReader, error) {
+	file, ok := reader.(*os.File)
+	if !ok {
+		return newFallbackInputReader(reader)
+	}
+
+	fd, err := unix.Dup(int(file.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("dup input fd: %w", err)
+	}
+
+	if err := unix.SetNonblock(fd, true); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("set nonblocking mode: %w", err)
+	}
+
+	// Re-open the duped fd through os.NewFile so the runtime poller picks it up.
+	pollable := os.NewFile(uintptr(fd), file.Name())
+
+	return &posixInputReader{file: pollable}, nil
+}
+
+type posixInputReader struct {
+	file *os.File
+	cancelMixin
+}
+
+func (r *posixInputReader) ReadInput() ([]Msg, error) {
+	if r.isCancelled() {
+		return nil, errCanceled
+	}
+
+	msg, err := parseInputMsgFromReader(r.file)
+	if err != nil {
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return nil, errCanceled
+		}
+
+		return nil, err
+	}
+
+	return []Msg{msg}, nil
+}
+
+func (r *posixInputReader) Cancel() bool {
+	r.setCancelled()
+
+	// Shifting the deadline into the past unblocks any in-flight Read with
+	// os.ErrDeadlineExceeded, which ReadInput translates into errCanceled.
+	return r.file.SetReadDeadline(time.Unix(1, 0)) == nil
+}
+
+func (r *posixInputReader) Close() error {
+	_ = unix.SetNonblock(int(r.file.Fd()), false)
+	return r.file.Close()
+}
@@ -0,0 +1,277 @@
+//go:build windows
+// +build windows
+
+// nolint:revive
+package tea
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	kernel32             = windows.NewLazySystemDLL("kernel32.dll")
+	procReadConsoleInput = kernel32.NewProc("ReadConsoleInputW")
+)
+
+const (
+	keyEvent              uint16 = 0x0001
+	mouseEvent            uint16 = 0x0002
+	windowBufferSizeEvent uint16 = 0x0004
+)
+
+// coord mirrors the Win32 COORD struct.
+type coord struct {
+	X, Y int16
+}
+
+// keyEventRecord mirrors the Win32 KEY_EVENT_RECORD struct.
+type keyEventRecord struct {
+	KeyDown         int32
+	RepeatCount     uint16
+	VirtualKeyCode  uint16
+	VirtualScanCode uint16
+	UnicodeChar     uint16
+	ControlKeyState uint32
+}
+
+// mouseEventRecord mirrors the Win32 MOUSE_EVENT_RECORD struct.
+type mouseEventRecord struct {
+	MousePosition   coord
+	ButtonState     uint32
+	ControlKeyState uint32
+	EventFlags      uint32
+}
+
+// windowBufferSizeRecord mirrors the Win32 WINDOW_BUFFER_SIZE_RECORD struct.
+type windowBufferSizeRecord struct {
+	Size coord
+}
+
+// inputRecord mirrors the Win32 INPUT_RECORD union. We only ever inspect the
+// EventType and decode the union manually from the raw bytes, since cgo-style
+// unions aren't representable directly in Go.
+type inputRecord struct {
+	EventType uint16
+	_         uint16 // padding to match the native struct layout
+	Event     [16]byte
+}
+
+func (e *inputRecord) toMsg() (Msg, bool) {
+	switch e.EventType {
+	case keyEvent:
+		k := (*keyEventRecord)(unsafe.Pointer(&e.Event[0]))
+		if k.KeyDown == 0 {
+			return nil, false
+		}
+		return keyMsgFromVirtualKeyEvent(k), true
+	case mouseEvent:
+		m := (*mouseEventRecord)(unsafe.Pointer(&e.Event[0]))
+		return mouseMsgFromRecord(m), true
+	case windowBufferSizeEvent:
+		w := (*windowBufferSizeRecord)(unsafe.Pointer(&e.Event[0]))
+		return WindowSizeMsg{Width: int(w.Size.X), Height: int(w.Size.Y)}, true
+	default:
+		return nil, false
+	}
+}
+
+// WindowSizeMsg is sent when the terminal window is resized.
+type WindowSizeMsg struct {
+	Width  int
+	Height int
+}
+
+// MouseMsg represents a mouse event.
+type MouseMsg struct {
+	X, Y int
+}
+
+func mouseMsgFromRecord(m *mouseEventRecord) MouseMsg {
+	return MouseMsg{X: int(m.MousePosition.X), Y: int(m.MousePosition.Y)}
+}
+
+// virtual-key codes for keys that don't have a printable rune.
+const (
+	vkBack   = 0x08
+	vkTab    = 0x09
+	vkReturn = 0x0d
+	vkEscape = 0x1b
+	vkUp     = 0x26
+	vkDown   = 0x28
+	vkLeft   = 0x25
+	vkRight  = 0x27
+)
+
+var vkNames = map[uint16]string{
+	vkBack:   "backspace",
+	vkTab:    "tab",
+	vkReturn: "enter",
+	vkEscape: "esc",
+	vkUp:     "up",
+	vkDown:   "down",
+	vkLeft:   "left",
+	vkRight:  "right",
+}
+
+// ControlKeyState flags, as reported by KEY_EVENT_RECORD.
+const (
+	leftAltPressed   = 0x0002
+	rightAltPressed  = 0x0001
+	leftCtrlPressed  = 0x0008
+	rightCtrlPressed = 0x0004
+)
+
+// modifierPrefix returns the "ctrl+"/"alt+" prefix implied by state, in the
+// same order the rest of the codebase composes them (ctrl before alt).
+func modifierPrefix(state uint32) string {
+	var prefix string
+
+	if state&(leftCtrlPressed|rightCtrlPressed) != 0 {
+		prefix += "ctrl+"
+	}
+	if state&(leftAltPressed|rightAltPressed) != 0 {
+		prefix += "alt+"
+	}
+
+	return prefix
+}
+
+func keyMsgFromVirtualKeyEvent(k *keyEventRecord) KeyMsg {
+	prefix := modifierPrefix(k.ControlKeyState)
+
+	if name, ok := vkNames[k.VirtualKeyCode]; ok {
+		return KeyMsg(prefix + name)
+	}
+
+	if k.UnicodeChar != 0 {
+		// Ctrl+<letter> is delivered as the corresponding C0 control code
+		// (e.g. Ctrl+C as 0x03) rather than as the letter itself with
+		// LEFT_CTRL_PRESSED set, so recover the letter from the control code
+		// instead of emitting it as a literal control rune.
+		r := rune(k.UnicodeChar)
+		if prefix != "" && r < 0x20 {
+			r = r + 'a' - 1
+		}
+
+		return KeyMsg(prefix + string(r))
+	}
+
+	return KeyMsg(prefix)
+}
+
+func readConsoleInput(handle windows.Handle, records []inputRecord, read *uint32) error {
+	r, _, err := procReadConsoleInput.Call(
+		uintptr(handle),
+		uintptr(unsafe.Pointer(&records[0])),
+		uintptr(len(records)),
+		uintptr(unsafe.Pointer(read)),
+	)
+	if r == 0 {
+		return err
+	}
+
+	return nil
+}
+
+// newInputReader returns a cancelable input reader. If the passed reader is an
+// *os.File backed by a Windows console, the cancel method can be used to
+// interrupt a blocking read call. In this case, the cancel method returns
+// true if the call was cancelled successfully. If the input reader is not a
+// console, the cancel function does nothing and always returns false. The
+// Windows implementation is based on ReadConsoleInputW.
+func newInputReader(reader io.Reader) (inputReader, error) {
+	file, ok := reader.(*os.File)
+	if !ok {
+		return newFallbackInputReader(reader)
+	}
+
+	handle := windows.Handle(file.Fd())
+
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		// Not a console, fall back to the generic reader.
+		return newFallbackInputReader(reader)
+	}
+
+	newMode := mode &^ (windows.ENABLE_ECHO_INPUT | windows.ENABLE_LINE_INPUT | windows.ENABLE_PROCESSED_INPUT)
+	newMode |= windows.ENABLE_WINDOW_INPUT | windows.ENABLE_MOUSE_INPUT
+	if err := windows.SetConsoleMode(handle, newMode); err != nil {
+		return nil, fmt.Errorf("set console mode: %w", err)
+	}
+
+	event, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create cancel event: %w", err)
+	}
+
+	return &winInputReader{
+		file:        file,
+		handle:      handle,
+		cancelEvent: event,
+	}, nil
+}
+
+type winInputReader struct {
+	file   *os.File
+	handle windows.Handle
+	cancelMixin
+	cancelEvent windows.Handle
+}
+
+func (r *winInputReader) ReadInput() ([]Msg, error) {
+	if r.isCancelled() {
+		return nil, errCanceled
+	}
+
+	for {
+		event, err := windows.WaitForMultipleObjects([]windows.Handle{r.handle, r.cancelEvent}, false, windows.INFINITE)
+		if err != nil {
+			return nil, fmt.Errorf("wait for console input: %w", err)
+		}
+
+		switch event {
+		case windows.WAIT_OBJECT_0:
+			// input is ready, read it below
+		case windows.WAIT_OBJECT_0 + 1:
+			return nil, errCanceled
+		default:
+			return nil, fmt.Errorf("unexpected wait result: %d", event)
+		}
+
+		var records [128]inputRecord
+		var read uint32
+		if err := readConsoleInput(r.handle, records[:], &read); err != nil {
+			return nil, fmt.Errorf("read console input: %w", err)
+		}
+
+		msgs := make([]Msg, 0, read)
+		for _, record := range records[:read] {
+			if msg, ok := record.toMsg(); ok {
+				msgs = append(msgs, msg)
+			}
+		}
+
+		if len(msgs) > 0 {
+			return msgs, nil
+		}
+		// nothing translatable in this batch (e.g. a key-up event), wait again
+	}
+}
+
+func (r *winInputReader) Cancel() bool {
+	r.setCancelled()
+
+	// ReadInput blocks in WaitForMultipleObjects on r.handle and
+	// r.cancelEvent, not on an overlapped read, so CancelIoEx has nothing to
+	// interrupt here. Signal the event the wait is actually listening on.
+	return windows.SetEvent(r.cancelEvent) == nil
+}
+
+func (r *winInputReader) Close() error {
+	return windows.CloseHandle(r.cancelEvent)
+}
@@ -0,0 +1,433 @@
+package tea
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
+)
+
+// Msg represents an action and is usually the result of an IO operation. It's
+// triggers the  Update function, and henceforth, the UI.
+type Msg interface{}
+
+// Model contains the program's state.
+type Model interface{}
+
+// Cmd is an IO operation that runs once. If it's nil it's considered a no-op.
+type Cmd func() Msg
+
+// Batch peforms a bunch of commands concurrently with no ordering guarantees
+// about the results.
+func Batch(cmds ...Cmd) Cmd {
+	if len(cmds) == 0 {
+		return nil
+	}
+	return func() Msg {
+		return batchMsg(cmds)
+	}
+}
+
+// Init is the first function that will be called. It returns your initial
+// model and runs an optional command
+type Init func() (Model, Cmd)
+
+// Update is called when a message is received. It may update the model and/or
+// send a command.
+type Update func(Msg, Model) (Model, Cmd)
+
+// View produces a string which will be rendered to the terminal
+type View func(Model) string
+
+// defaultShutdownTimeout bounds how long Start waits for the input and
+// command goroutines to drain after a quit before giving up and logging the
+// stragglers.
+const defaultShutdownTimeout = 5 * time.Second
+
+// Program is a terminal user interface
+type Program struct {
+	init   Init
+	update Update
+	view   View
+
+	input           io.Reader
+	output          io.Writer
+	withoutRenderer bool
+	shutdownTimeout time.Duration
+
+	kill     chan struct{}
+	killOnce sync.Once
+}
+
+// ProgramOption is used to set options when initializing a Program. Program
+// can accept a variable number of options.
+//
+// Example usage:
+//
+//	p := NewProgram(Init, Update, View, WithInput(someInput), WithOutput(someOutput))
+type ProgramOption func(*Program)
+
+// WithInput sets the input reader a Program reads key events from. The
+// default is os.Stdin. When the given reader is not a terminal, the Program
+// falls back to headless mode: it skips raw-mode setup and instead parses
+// newline-delimited key names from the reader.
+func WithInput(input io.Reader) ProgramOption {
+	return func(p *Program) {
+		p.input = input
+	}
+}
+
+// WithOutput sets the output writer a Program renders to. The default is
+// os.Stdout.
+func WithOutput(output io.Writer) ProgramOption {
+	return func(p *Program) {
+		p.output = output
+	}
+}
+
+// WithoutRenderer disables rendering altogether. This is useful in
+// combination with WithInput for driving a Program from a script or a test
+// where only the resulting Msg/Model exchange matters.
+func WithoutRenderer() ProgramOption {
+	return func(p *Program) {
+		p.withoutRenderer = true
+	}
+}
+
+// WithShutdownTimeout overrides how long Start waits, after a quit, for the
+// input and command goroutines to finish before giving up and logging the
+// stragglers. The default is five seconds.
+func WithShutdownTimeout(d time.Duration) ProgramOption {
+	return func(p *Program) {
+		p.shutdownTimeout = d
+	}
+}
+
+// Quit is a command that tells the program to exit
+func Quit() Msg {
+	return quitMsg{}
+}
+
+// Signals that the program should quit
+type quitMsg struct{}
+
+// batchMsg is used to perform a bunch of commands
+type batchMsg []Cmd
+
+// NewProgram creates a new Program
+func NewProgram(init Init, update Update, view View, opts ...ProgramOption) *Program {
+	p := &Program{
+		init:            init,
+		update:          update,
+		view:            view,
+		input:           os.Stdin,
+		output:          os.Stdout,
+		shutdownTimeout: defaultShutdownTimeout,
+		kill:            make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Kill stops the program from outside the Update loop, following the same
+// graceful shutdown path as sending Quit from within it. It's safe to call
+// more than once and from any goroutine.
+func (p *Program) Kill() {
+	p.killOnce.Do(func() {
+		close(p.kill)
+	})
+}
+
+// isHeadless reports whether the program's input is not an interactive
+// terminal, in which case raw-mode setup is skipped and input is driven by
+// newline-delimited key names instead of raw key events.
+func (p *Program) isHeadless() bool {
+	file, ok := p.input.(*os.File)
+	if !ok {
+		return true
+	}
+
+	return !term.IsTerminal(int(file.Fd()))
+}
+
+// Start initializes the program
+func (p *Program) Start() error {
+	var (
+		model         Model
+		cmd           Cmd
+		cmds          = make(chan Cmd)
+		msgs          = make(chan Msg)
+		errs          = make(chan error, 1)
+		done          = make(chan struct{})
+		linesRendered int
+		headless      = p.isHeadless()
+
+		inputWG sync.WaitGroup
+		cmdWG   sync.WaitGroup
+	)
+
+	if !headless {
+		if err := initTerminal(); err != nil {
+			return err
+		}
+		defer restoreTerminal()
+	}
+
+	runCmd := func(cmd Cmd) {
+		if cmd == nil {
+			return
+		}
+		cmdWG.Add(1)
+		go func() {
+			defer cmdWG.Done()
+			select {
+			case msgs <- cmd():
+			case <-done:
+			}
+		}()
+	}
+
+	// Initialize program
+	model, cmd = p.init()
+	runCmd(cmd)
+
+	// Render initial view
+	linesRendered = p.render(model, linesRendered, headless)
+
+	// Subscribe to user input. In interactive mode the reader is cancelable
+	// so that Start can interrupt the blocking read on shutdown instead of
+	// leaking the goroutine for the life of the process. In headless mode
+	// input is newline-delimited key names, cancelled by closing the
+	// underlying file.
+	var cancelInput func() bool
+
+	if headless {
+		cancelInput = p.startHeadlessInput(&inputWG, msgs, errs, done)
+	} else {
+		reader, err := newInputReader(p.input)
+		if err != nil {
+			return err
+		}
+		cancelInput = reader.Cancel
+
+		inputWG.Add(1)
+		go func() {
+			defer inputWG.Done()
+			defer reader.Close()
+
+			for {
+				in, err := reader.ReadInput()
+				if err != nil {
+					if err == errCanceled {
+						return
+					}
+
+					select {
+					case errs <- err:
+					case <-done:
+					}
+					return
+				}
+
+				for _, msg := range in {
+					select {
+					case msgs <- msg:
+					case <-done:
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	// Process commands, spawning one goroutine per command and tracking it
+	// in cmdWG so Start can wait for in-flight commands to finish on
+	// shutdown instead of leaking them.
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case cmd := <-cmds:
+				runCmd(cmd)
+			}
+		}
+	}()
+
+	shutdown := func() error {
+		close(done)
+		cancelInput()
+
+		waited := make(chan struct{})
+		go func() {
+			inputWG.Wait()
+			cmdWG.Wait()
+			close(waited)
+		}()
+
+		select {
+		case <-waited:
+		case <-time.After(p.shutdownTimeout):
+			fmt.Fprintln(p.output, "tea: shutdown timed out waiting for goroutines to exit")
+		}
+
+		return nil
+	}
+
+	// Handle updates and draw
+	for {
+		select {
+		case <-p.kill:
+			return shutdown()
+		case err := <-errs:
+			_ = shutdown()
+			return err
+		case msg := <-msgs:
+
+			// Handle quit message
+			if _, ok := msg.(quitMsg); ok {
+				return shutdown()
+			}
+
+			// Process batch commands
+			if batchedCmds, ok := msg.(batchMsg); ok {
+				for _, cmd := range batchedCmds {
+					select {
+					case cmds <- cmd:
+					case <-done:
+					}
+				}
+				continue
+			}
+
+			model, cmd = p.update(msg, model) // run update
+
+			select {
+			case cmds <- cmd: // process command (if any)
+			case <-done:
+			}
+
+			linesRendered = p.render(model, linesRendered, headless) // render to terminal
+		}
+	}
+}
+
+// startHeadlessInput drives msgs from newline-delimited key names read from
+// p.input. It returns a cancel function that unblocks the read by closing
+// the underlying file, if the input reader supports it.
+func (p *Program) startHeadlessInput(wg *sync.WaitGroup, msgs chan<- Msg, errs chan<- error, done <-chan struct{}) func() bool {
+	closer, _ := p.input.(io.Closer)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		scanner := bufio.NewScanner(p.input)
+		for scanner.Scan() {
+			select {
+			case msgs <- KeyMsg(scanner.Text()):
+			case <-done:
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			select {
+			case errs <- err:
+			case <-done:
+			}
+		}
+	}()
+
+	return func() bool {
+		if closer == nil {
+			return false
+		}
+		return closer.Close() == nil
+	}
+}
+
+// Render a view to the program's output. Returns the number of lines the next
+// render has to clear: the lines written to the terminal, or 0 when rendering
+// is disabled or the program is headless.
+func (p *Program) render(model Model, linesRendered int, headless bool) int {
+	if p.withoutRenderer {
+		return 0
+	}
+
+	view := p.view(model)
+
+	if headless {
+		// There's no terminal to move the cursor around in, so just print
+		// the view as plain text, one render per line.
+		_, _ = io.WriteString(p.output, view+"\n")
+		return 0
+	}
+
+	// We need to add carriage returns to ensure that the cursor travels to the
+	// start of a column after a newline
+	view = strings.Replace(view, "\n", "\r\n", -1)
+
+	if linesRendered > 0 {
+		termenv.ClearLines(linesRendered)
+	}
+	_, _ = io.WriteString(p.output, view)
+	return strings.Count(view, "\r\n")
+}
+
+// AltScreen enters the altscreen. This is just a wrapper around the termenv
+// function
+func AltScreen() {
+	termenv.AltScreen()
+}
+
+// ExitAltScreen exits the altscreen. This is just a wrapper around the termenv
+// function
+func ExitAltScreen() {
+	termenv.ExitAltScreen()
+}
+
+type EveryMsg time.Time
+
+// Every is a command that ticks in sync with the system clock. So, if you
+// wanted to tick with the system clock every second, minute or hour you
+// could use this. It's also handy for having different things tick in sync.
+//
+// Note that because we're ticking with the system clock the tick will likely
+// not run for the entire specified duration. For example, if we're ticking for
+// one minute and the clock is at 12:34:20 then the next tick will happen at
+// 12:35:00, 40 seconds later.
+func Every(duration time.Duration, fn func(time.Time) Msg) Cmd {
+	return func() Msg {
+		n := time.Now()
+		d := n.Truncate(duration).Add(duration).Sub(n)
+		t := time.NewTimer(d)
+		select {
+		case now := <-t.C:
+			return fn(now)
+		}
+	}
+}
+
+// Tick is a subscription that at an interval independent of the system clock
+// at the given duration. That is, the timer begins when precisely when invoked,
+// and runs for its entire duration.
+func Tick(d time.Duration, fn func(time.Time) Msg) Cmd {
+	return func() Msg {
+		t := time.NewTimer(d)
+		select {
+		case now := <-t.C:
+			return fn(now)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package tea
+
+import (
+	"io"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func testProgram(input io.Reader, opts ...ProgramOption) *Program {
+	init := func() (Model, Cmd) { return nil, nil }
+	update := func(Msg, Model) (Model, Cmd) { return nil, nil }
+	view := func(Model) string { return "" }
+
+	opts = append([]ProgramOption{WithInput(input), WithoutRenderer()}, opts...)
+	return NewProgram(init, update, view, opts...)
+}
+
+// waitForGoroutines polls runtime.NumGoroutine until it settles at or below
+// baseline, or the timeout elapses.
+func waitForGoroutines(t *testing.T, baseline int, timeout time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if runtime.NumGoroutine() <= baseline {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Errorf("goroutines leaked: have %d, want <= %d", runtime.NumGoroutine(), baseline)
+}
+
+func TestProgramKillReapsGoroutines(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	r, w := io.Pipe()
+	defer w.Close()
+
+	p := testProgram(r)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.Start()
+	}()
+
+	// give Start a moment to spin up its goroutines before asking it to quit
+	time.Sleep(50 * time.Millisecond)
+
+	go func() {
+		_, _ = w.Write([]byte(""))
+	}()
+	p.Kill()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Start returned error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Start did not return after Kill")
+	}
+
+	waitForGoroutines(t, baseline, time.Second)
+}
+
+func TestProgramKillIsIdempotent(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	p := testProgram(r)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.Start()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	p.Kill()
+	p.Kill() // must not panic or block
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Start did not return after Kill")
+	}
+}
@@ -0,0 +1,536 @@
+// Package textinput provides a single-line text input component, in the
+// style of the Elm Architecture, with an optional readline-style editing
+// mode (history, kill-ring and completion) for programs that need
+// shell-grade line editing.
+package textinput
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/tea"
+	te "github.com/muesli/termenv"
+)
+
+const defaultBlinkSpeed = time.Millisecond * 530
+
+// Completer proposes completions for the text to the left of the cursor. It
+// returns the candidates along with the rune offset the replacement should
+// start at. If there is exactly one candidate it's inserted directly; if
+// there are several, a CompletionsMsg is sent so the caller can render a
+// menu. No candidates leave the input unchanged.
+type Completer func(line string, pos int) (candidates []string, replaceFrom int)
+
+// CompletionsMsg is sent when a Completer returns more than one candidate.
+type CompletionsMsg struct {
+	Candidates  []string
+	ReplaceFrom int
+}
+
+// KeyMap defines the keys recognized for readline-style editing. Each field
+// is a list of key strings, as reported by KeyMsg.String(), that trigger the
+// corresponding action.
+type KeyMap struct {
+	CharacterForward    []string
+	CharacterBackward   []string
+	WordForward         []string
+	WordBackward        []string
+	LineStart           []string
+	LineEnd             []string
+	DeleteCharBackward  []string
+	DeleteWordBackward  []string
+	Kill                []string
+	Yank                []string
+	HistoryPrev         []string
+	HistoryNext         []string
+	HistorySearch       []string
+	HistorySearchCancel []string
+	YankPop             []string
+	Completion          []string
+}
+
+// DefaultKeyMap is the KeyMap used by NewModel.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		CharacterForward:    []string{"right", "ctrl+f"},
+		CharacterBackward:   []string{"left", "ctrl+b"},
+		WordForward:         []string{"alt+f"},
+		WordBackward:        []string{"alt+b"},
+		LineStart:           []string{"ctrl+a", "home"},
+		LineEnd:             []string{"ctrl+e", "end"},
+		DeleteCharBackward:  []string{"backspace"},
+		DeleteWordBackward:  []string{"ctrl+w", "alt+backspace"},
+		Kill:                []string{"ctrl+k"},
+		Yank:                []string{"ctrl+y"},
+		HistoryPrev:         []string{"up"},
+		HistoryNext:         []string{"down"},
+		HistorySearch:       []string{"ctrl+r"},
+		HistorySearchCancel: []string{"esc", "ctrl+g"},
+		YankPop:             []string{"alt+y"},
+		Completion:          []string{"tab"},
+	}
+}
+
+// maxKillRingSize bounds how many killed spans are kept for ctrl+y/alt+y.
+const maxKillRingSize = 32
+
+// Model is the state of a text input.
+type Model struct {
+	Prompt           string
+	Placeholder      string
+	TextColor        string
+	PlaceholderColor string
+	CursorColor      string
+	CharLimit        int
+	Width            int
+
+	// History, when non-nil, is searched and navigated with KeyMap.HistoryPrev
+	// / HistoryNext / HistorySearch. Entries are appended on submit.
+	History []string
+
+	// HistoryFile, if set, is loaded into History by LoadHistory and appended
+	// to on every submitted, non-empty line.
+	HistoryFile string
+
+	// Completer, if set, is invoked on KeyMap.Completion.
+	Completer Completer
+
+	KeyMap KeyMap
+
+	focus bool
+	blink bool
+
+	value  []rune
+	cursor int
+
+	historyIdx int    // index into History while browsing; -1 when not browsing
+	historyBuf string // the line being edited before HistoryPrev was pressed
+
+	searching    bool   // whether a ctrl+r history search is in progress
+	searchTerm   string // the incremental search query
+	searchIdx    int    // the History index to resume searching backward from
+	searchOrigin string // the value to restore if the search is cancelled
+
+	killRing           []string // most recently killed spans, most recent first
+	killRingIdx        int      // the ring entry currently yanked into the buffer
+	lastActionWasYank  bool     // whether alt+y may rotate the ring right now
+	yankStart, yankEnd int      // the rune range of the last inserted yank
+}
+
+// NewModel creates a new Model with sensible defaults.
+func NewModel() Model {
+	return Model{
+		Prompt:           "> ",
+		CharLimit:        0,
+		PlaceholderColor: "240",
+		KeyMap:           DefaultKeyMap(),
+		historyIdx:       -1,
+	}
+}
+
+// Value returns the current value of the input.
+func (m Model) Value() string {
+	return string(m.value)
+}
+
+// SetValue sets the value of the input and moves the cursor to the end.
+func (m *Model) SetValue(s string) {
+	m.value = []rune(s)
+	m.cursor = len(m.value)
+}
+
+// Focused returns whether the input is focused.
+func (m Model) Focused() bool {
+	return m.focus
+}
+
+// Focus sets the focus state on the model.
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+// Blur removes the focus state on the model.
+func (m *Model) Blur() {
+	m.focus = false
+	m.blink = true
+}
+
+// LoadHistory loads HistoryFile into History, one entry per line, regardless
+// of whatever History already holds. Call it once HistoryFile is set,
+// typically right after NewModel, e.g. from your program's Init:
+//
+//	m := textinput.NewModel()
+//	m.HistoryFile = "/path/to/history"
+//	m.LoadHistory()
+func (m *Model) LoadHistory() {
+	if m.HistoryFile == "" {
+		return
+	}
+
+	f, err := os.Open(m.HistoryFile)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			m.History = append(m.History, line)
+		}
+	}
+}
+
+// appendHistoryFile appends a submitted line to HistoryFile, if set.
+func (m *Model) appendHistoryFile(line string) {
+	if m.HistoryFile == "" || line == "" {
+		return
+	}
+
+	f, err := os.OpenFile(m.HistoryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	_, _ = f.WriteString(line + "\n")
+}
+
+// Submit records the current value in History (and HistoryFile, if set),
+// resets history navigation and returns the submitted value.
+func (m *Model) Submit() string {
+	line := m.Value()
+	if line != "" {
+		m.History = append(m.History, line)
+		m.appendHistoryFile(line)
+	}
+	m.historyIdx = -1
+	m.historyBuf = ""
+	return line
+}
+
+func matchesAny(key string, keys []string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Update is the Bubble Tea update loop for this component.
+func Update(msg tea.Msg, m Model) (Model, tea.Cmd) {
+	if !m.focus {
+		m.blink = false
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+
+		if m.searching {
+			m.handleSearchKey(key)
+			return m, nil
+		}
+
+		prevWasYank := m.lastActionWasYank
+		m.lastActionWasYank = false
+
+		switch {
+		case matchesAny(key, m.KeyMap.CharacterForward):
+			if m.cursor < len(m.value) {
+				m.cursor++
+			}
+		case matchesAny(key, m.KeyMap.CharacterBackward):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case matchesAny(key, m.KeyMap.WordForward):
+			for m.cursor < len(m.value) && m.value[m.cursor] == ' ' {
+				m.cursor++
+			}
+			for m.cursor < len(m.value) && m.value[m.cursor] != ' ' {
+				m.cursor++
+			}
+		case matchesAny(key, m.KeyMap.WordBackward):
+			m.cursor = wordBackward(m.value, m.cursor)
+		case matchesAny(key, m.KeyMap.LineStart):
+			m.cursor = 0
+		case matchesAny(key, m.KeyMap.LineEnd):
+			m.cursor = len(m.value)
+		case matchesAny(key, m.KeyMap.DeleteCharBackward):
+			if m.cursor > 0 {
+				m.value = append(m.value[:m.cursor-1], m.value[m.cursor:]...)
+				m.cursor--
+			}
+		case matchesAny(key, m.KeyMap.DeleteWordBackward):
+			start := wordBackward(m.value, m.cursor)
+			m.pushKill(string(m.value[start:m.cursor]))
+			m.value = append(m.value[:start], m.value[m.cursor:]...)
+			m.cursor = start
+		case matchesAny(key, m.KeyMap.Kill):
+			m.pushKill(string(m.value[m.cursor:]))
+			m.value = m.value[:m.cursor]
+		case matchesAny(key, m.KeyMap.Yank):
+			m = m.yank()
+			m.lastActionWasYank = true
+		case matchesAny(key, m.KeyMap.YankPop):
+			if prevWasYank {
+				m = m.yankPop()
+				m.lastActionWasYank = true
+			}
+		case matchesAny(key, m.KeyMap.HistoryPrev):
+			m.historyPrev()
+		case matchesAny(key, m.KeyMap.HistoryNext):
+			m.historyNext()
+		case matchesAny(key, m.KeyMap.HistorySearch):
+			m.searching = true
+			m.searchTerm = ""
+			m.searchOrigin = m.Value()
+			m.searchIdx = len(m.History) - 1
+		case matchesAny(key, m.KeyMap.Completion):
+			return m.complete()
+		default:
+			if len(key) == 1 || isPrintable(key) {
+				m = m.insert(key)
+			}
+		}
+
+		if m.CharLimit > 0 && len(m.value) > m.CharLimit {
+			m.value = m.value[:m.CharLimit]
+			if m.cursor > len(m.value) {
+				m.cursor = len(m.value)
+			}
+		}
+
+	case BlinkMsg:
+		m.blink = !m.blink
+		return m, Blink(m)
+	}
+
+	return m, nil
+}
+
+// insert inserts s at the cursor and advances the cursor past it.
+func (m Model) insert(s string) Model {
+	runes := []rune(s)
+	value := make([]rune, 0, len(m.value)+len(runes))
+	value = append(value, m.value[:m.cursor]...)
+	value = append(value, runes...)
+	value = append(value, m.value[m.cursor:]...)
+	m.value = value
+	m.cursor += len(runes)
+	return m
+}
+
+// historyPrev moves one entry back in History, saving the in-progress line
+// the first time it's called.
+func (m *Model) historyPrev() {
+	if len(m.History) == 0 {
+		return
+	}
+
+	if m.historyIdx == -1 {
+		m.historyBuf = m.Value()
+		m.historyIdx = len(m.History)
+	}
+
+	if m.historyIdx == 0 {
+		return
+	}
+
+	m.historyIdx--
+	m.SetValue(m.History[m.historyIdx])
+}
+
+// historyNext moves one entry forward in History, restoring the in-progress
+// line once the end of History is reached again.
+func (m *Model) historyNext() {
+	if m.historyIdx == -1 {
+		return
+	}
+
+	m.historyIdx++
+	if m.historyIdx >= len(m.History) {
+		m.historyIdx = -1
+		m.SetValue(m.historyBuf)
+		return
+	}
+
+	m.SetValue(m.History[m.historyIdx])
+}
+
+// pushKill records a killed span at the front of the kill-ring, ready to be
+// yanked with KeyMap.Yank and rotated through with KeyMap.YankPop.
+func (m *Model) pushKill(s string) {
+	if s == "" {
+		return
+	}
+
+	m.killRing = append([]string{s}, m.killRing...)
+	if len(m.killRing) > maxKillRingSize {
+		m.killRing = m.killRing[:maxKillRingSize]
+	}
+}
+
+// yank inserts the most recently killed span at the cursor and remembers
+// its extent so a following YankPop can replace it.
+func (m Model) yank() Model {
+	if len(m.killRing) == 0 {
+		return m
+	}
+
+	m.killRingIdx = 0
+	start := m.cursor
+	m = m.insert(m.killRing[0])
+	m.yankStart, m.yankEnd = start, m.cursor
+	return m
+}
+
+// yankPop replaces the span inserted by the previous Yank/YankPop with the
+// next older entry in the kill-ring.
+func (m Model) yankPop() Model {
+	if len(m.killRing) == 0 {
+		return m
+	}
+
+	m.killRingIdx = (m.killRingIdx + 1) % len(m.killRing)
+	replacement := []rune(m.killRing[m.killRingIdx])
+
+	value := make([]rune, 0, len(m.value)-(m.yankEnd-m.yankStart)+len(replacement))
+	value = append(value, m.value[:m.yankStart]...)
+	value = append(value, replacement...)
+	value = append(value, m.value[m.yankEnd:]...)
+
+	m.value = value
+	m.cursor = m.yankStart + len(replacement)
+	m.yankEnd = m.cursor
+	return m
+}
+
+// handleSearchKey processes a key while an incremental ctrl+r history search
+// is in progress: printable keys extend the query, KeyMap.HistorySearch
+// looks further back for the next match, backspace shortens the query, and
+// enter/KeyMap.HistorySearchCancel end the search (accepting or discarding
+// the match respectively).
+func (m *Model) handleSearchKey(key string) {
+	switch {
+	case matchesAny(key, m.KeyMap.HistorySearchCancel):
+		m.SetValue(m.searchOrigin)
+		m.searching = false
+	case key == "enter":
+		m.searching = false
+	case matchesAny(key, m.KeyMap.HistorySearch):
+		m.searchIdx--
+		m.searchHistory()
+	case matchesAny(key, m.KeyMap.DeleteCharBackward):
+		if len(m.searchTerm) > 0 {
+			m.searchTerm = m.searchTerm[:len(m.searchTerm)-1]
+		}
+		m.searchIdx = len(m.History) - 1
+		m.searchHistory()
+	default:
+		if len(key) == 1 || isPrintable(key) {
+			m.searchTerm += key
+			m.searchIdx = len(m.History) - 1
+			m.searchHistory()
+		}
+	}
+}
+
+// searchHistory looks backward from searchIdx for the most recent History
+// entry containing searchTerm and, if found, makes it the current value.
+func (m *Model) searchHistory() {
+	for i := m.searchIdx; i >= 0; i-- {
+		if strings.Contains(m.History[i], m.searchTerm) {
+			m.searchIdx = i
+			m.SetValue(m.History[i])
+			return
+		}
+	}
+}
+
+// complete invokes Completer with the text to the left of the cursor. A
+// single candidate is inserted directly; multiple candidates are surfaced as
+// a CompletionsMsg for the caller to render.
+func (m Model) complete() (Model, tea.Cmd) {
+	if m.Completer == nil {
+		return m, nil
+	}
+
+	candidates, from := m.Completer(string(m.value[:m.cursor]), m.cursor)
+
+	// Completer is caller-supplied and may return an out-of-range
+	// ReplaceFrom; clamp it before it's used to splice m.value.
+	if from < 0 {
+		from = 0
+	} else if from > m.cursor {
+		from = m.cursor
+	}
+
+	switch len(candidates) {
+	case 0:
+		return m, nil
+	case 1:
+		m.value = append(m.value[:from], m.value[m.cursor:]...)
+		m.cursor = from
+		m = m.insert(candidates[0])
+		return m, nil
+	default:
+		return m, func() tea.Msg {
+			return CompletionsMsg{Candidates: candidates, ReplaceFrom: from}
+		}
+	}
+}
+
+// wordBackward returns the rune index of the start of the word ending at
+// pos, skipping any trailing whitespace.
+func wordBackward(value []rune, pos int) int {
+	i := pos
+	for i > 0 && value[i-1] == ' ' {
+		i--
+	}
+	for i > 0 && value[i-1] != ' ' {
+		i--
+	}
+	return i
+}
+
+func isPrintable(key string) bool {
+	return !strings.HasPrefix(key, "ctrl+") && !strings.HasPrefix(key, "alt+") &&
+		key != "up" && key != "down" && key != "left" && key != "right" &&
+		key != "home" && key != "end" && key != "tab" && key != "enter" && key != "esc"
+}
+
+// View renders the text input as a string.
+func View(m Model) string {
+	value := m.Value()
+
+	if value == "" && m.Placeholder != "" {
+		return m.Prompt + te.String(m.Placeholder).Foreground(te.ColorProfile().Color(m.PlaceholderColor)).String()
+	}
+
+	styled := value
+	if m.TextColor != "" {
+		styled = te.String(value).Foreground(te.ColorProfile().Color(m.TextColor)).String()
+	}
+
+	return m.Prompt + styled
+}
+
+// BlinkMsg is sent on every cursor blink tick.
+type BlinkMsg struct{}
+
+// Blink is a command used to manage the blinking of the cursor.
+func Blink(m Model) tea.Cmd {
+	if !m.focus {
+		return nil
+	}
+
+	return tea.Tick(defaultBlinkSpeed, func(time.Time) tea.Msg {
+		return BlinkMsg{}
+	})
+}